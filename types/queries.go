@@ -3,6 +3,7 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"sync"
 )
 
 //-------- Queries --------
@@ -67,10 +68,64 @@ func RustQuery(querier Querier, binRequest []byte, gasLimit uint64) QuerierResul
 			},
 		}
 	}
+	if request.Batch != nil {
+		return runBatchQuery(querier, *request.Batch, gasLimit)
+	}
 	bz, err := querier.Query(request, gasLimit)
 	return ToQuerierResult(bz, err)
 }
 
+// maxBatchQueries caps BatchQuery.Requests so a single batch cannot force the host to
+// accumulate an unbounded BatchResponse, independent of whether gas exhaustion would
+// eventually have stopped it. Mirrors the rationale behind RawRangeQuery.Limit.
+const maxBatchQueries = 100
+
+// runBatchQuery dispatches each sub-request of a BatchQuery against the querier in turn,
+// charging every sub-query for its own share of gasLimit. Gas usage per sub-query is
+// measured as the delta of querier.GasConsumed() around the Query call, so a single
+// expensive sub-query is blamed correctly instead of smearing its cost over the batch.
+func runBatchQuery(querier Querier, batch BatchQuery, gasLimit uint64) QuerierResult {
+	if len(batch.Requests) > maxBatchQueries {
+		return ToQuerierResult(nil, fmt.Errorf("batch query has %d sub-queries, which exceeds the max of %d", len(batch.Requests), maxBatchQueries))
+	}
+
+	remainingGas := gasLimit
+	results := make([]QueryResult, 0, len(batch.Requests))
+	for _, sub := range batch.Requests {
+		if sub.Batch != nil {
+			err := fmt.Errorf("nested batch queries are not supported")
+			if batch.StopOnError {
+				return ToQuerierResult(nil, err)
+			}
+			results = append(results, QueryResult{Err: err.Error()})
+			continue
+		}
+
+		before := querier.GasConsumed()
+		bz, err := querier.Query(sub, remainingGas)
+		used := querier.GasConsumed() - before
+		if used >= remainingGas {
+			remainingGas = 0
+		} else {
+			remainingGas -= used
+		}
+		if err != nil {
+			if batch.StopOnError {
+				return ToQuerierResult(nil, err)
+			}
+			results = append(results, QueryResult{Err: err.Error()})
+			continue
+		}
+		results = append(results, QueryResult{Ok: bz})
+	}
+
+	bz, err := json.Marshal(BatchResponse{Results: results})
+	if err != nil {
+		return ToQuerierResult(nil, err)
+	}
+	return ToQuerierResult(bz, nil)
+}
+
 // This is a 2-level result
 type QuerierResult struct {
 	Ok  *QueryResult `json:"ok,omitempty"`
@@ -98,7 +153,10 @@ func ToQuerierResult(response []byte, err error) QuerierResult {
 	}
 }
 
-// QueryRequest is an rust enum and only (exactly) one of the fields should be set
+// QueryRequest is an rust enum and exactly one of the variant fields (every field
+// except CacheHint) should be set. CacheHint is not itself a variant: it is an
+// optional modifier that may ride along with whichever variant field is set (e.g.
+// Bank + CacheHint together) to opt that query into the CachingQuerier cache.
 // Should we do a cleaner approach in Go? (type/data?)
 type QueryRequest struct {
 	Bank         *BankQuery         `json:"bank,omitempty"`
@@ -109,6 +167,13 @@ type QueryRequest struct {
 	Stargate     *StargateQuery     `json:"stargate,omitempty"`
 	Grpc         *GrpcQuery         `json:"grpc,omitempty"`
 	Wasm         *WasmQuery         `json:"wasm,omitempty"`
+	Feegrant     *FeegrantQuery     `json:"feegrant,omitempty"`
+	Auth         *AuthQuery         `json:"auth,omitempty"`
+	Batch        *BatchQuery        `json:"batch,omitempty"`
+	// CacheHint is an optional opt-in for the host to serve this query from a
+	// block-scoped cache instead of re-executing it. It has no effect unless the
+	// host's Querier is (or wraps) a CachingQuerier.
+	CacheHint *QueryCacheHint `json:"cache_hint,omitempty"`
 }
 
 type BankQuery struct {
@@ -175,6 +240,20 @@ type IBCQuery struct {
 	PortID       *PortIDQuery       `json:"port_id,omitempty"`
 	ListChannels *ListChannelsQuery `json:"list_channels,omitempty"`
 	Channel      *ChannelQuery      `json:"channel,omitempty"`
+	// See <https://github.com/cosmos/ibc-go/blob/v7.0.0/proto/ibc/core/connection/v1/query.proto#L39-L47>
+	Connection *IBCConnectionQuery `json:"connection,omitempty"`
+	// See <https://github.com/cosmos/ibc-go/blob/v7.0.0/proto/ibc/core/client/v1/query.proto#L46-L55>
+	ClientState *IBCClientStateQuery `json:"client_state,omitempty"`
+	// See <https://github.com/cosmos/ibc-go/blob/v7.0.0/proto/ibc/core/client/v1/query.proto#L57-L67>
+	ConsensusState *IBCConsensusStateQuery `json:"consensus_state,omitempty"`
+	// See <https://github.com/cosmos/ibc-go/blob/v7.0.0/proto/ibc/core/channel/v1/query.proto#L101-L111>
+	PacketCommitment *IBCPacketCommitmentQuery `json:"packet_commitment,omitempty"`
+	// See <https://github.com/cosmos/ibc-go/blob/v7.0.0/proto/ibc/core/channel/v1/query.proto#L144-L154>
+	PacketAcknowledgement *IBCPacketAcknowledgementQuery `json:"packet_acknowledgement,omitempty"`
+	// See <https://github.com/cosmos/ibc-go/blob/v7.0.0/proto/ibc/core/channel/v1/query.proto#L189-L197>
+	NextSequenceReceive *IBCNextSequenceReceiveQuery `json:"next_sequence_receive,omitempty"`
+	// See <https://github.com/cosmos/ibc-go/blob/v7.0.0/proto/ibc/applications/transfer/v1/query.proto#L62-L70>
+	DenomTrace *IBCDenomTraceQuery `json:"denom_trace,omitempty"`
 }
 
 type PortIDQuery struct{}
@@ -207,6 +286,119 @@ type ChannelResponse struct {
 	Channel *IBCChannel `json:"channel,omitempty"`
 }
 
+type IBCConnectionQuery struct {
+	ConnectionID string `json:"connection_id"`
+}
+
+// IBCConnectionResponse is the expected response to IBCConnectionQuery
+type IBCConnectionResponse struct {
+	ClientID     string                    `json:"client_id"`
+	Counterparty IBCConnectionCounterparty `json:"counterparty"`
+	// Versions are the IBC connection versions negotiated during the handshake.
+	Versions []IBCConnectionVersion `json:"versions"`
+	// State is one of "STATE_UNINITIALIZED_UNSPECIFIED", "STATE_INIT",
+	// "STATE_TRYOPEN" or "STATE_OPEN".
+	State string `json:"state"`
+}
+
+type IBCConnectionCounterparty struct {
+	ClientID     string          `json:"client_id"`
+	ConnectionID string          `json:"connection_id"`
+	Prefix       IBCMerklePrefix `json:"prefix"`
+}
+
+// IBCMerklePrefix is the counterpart of `ibc.core.commitment.v1.MerklePrefix`.
+type IBCMerklePrefix struct {
+	KeyPrefix []byte `json:"key_prefix"`
+}
+
+type IBCConnectionVersion struct {
+	Identifier string   `json:"identifier"`
+	Features   []string `json:"features"`
+}
+
+type IBCClientStateQuery struct {
+	ClientID string `json:"client_id"`
+}
+
+// IBCClientStateResponse is the expected response to IBCClientStateQuery
+type IBCClientStateResponse struct {
+	// ClientState is a protobuf-encoded `Any` containing the client state
+	// (e.g. a Tendermint client state).
+	ClientState []byte    `json:"client_state"`
+	ProofHeight IBCHeight `json:"proof_height"`
+}
+
+type IBCConsensusStateQuery struct {
+	ClientID       string `json:"client_id"`
+	RevisionNumber uint64 `json:"revision_number"`
+	RevisionHeight uint64 `json:"revision_height"`
+}
+
+// IBCConsensusStateResponse is the expected response to IBCConsensusStateQuery
+type IBCConsensusStateResponse struct {
+	// ConsensusState is a protobuf-encoded `Any` containing the consensus state.
+	ConsensusState []byte    `json:"consensus_state"`
+	ProofHeight    IBCHeight `json:"proof_height"`
+}
+
+// IBCHeight is the height of a light client, expressed as a revision number and height
+// within that revision.
+type IBCHeight struct {
+	RevisionNumber uint64 `json:"revision_number"`
+	RevisionHeight uint64 `json:"revision_height"`
+}
+
+type IBCPacketCommitmentQuery struct {
+	PortID    string `json:"port_id"`
+	ChannelID string `json:"channel_id"`
+	Sequence  uint64 `json:"sequence"`
+}
+
+// IBCPacketCommitmentResponse is the expected response to IBCPacketCommitmentQuery
+type IBCPacketCommitmentResponse struct {
+	// Commitment is the SHA-256 hash committed to when the packet was sent.
+	// May be empty if no commitment exists for this sequence (e.g. already acked).
+	Commitment []byte `json:"commitment,omitempty"`
+}
+
+type IBCPacketAcknowledgementQuery struct {
+	PortID    string `json:"port_id"`
+	ChannelID string `json:"channel_id"`
+	Sequence  uint64 `json:"sequence"`
+}
+
+// IBCPacketAcknowledgementResponse is the expected response to IBCPacketAcknowledgementQuery
+type IBCPacketAcknowledgementResponse struct {
+	// Acknowledgement is the raw acknowledgement bytes the receiving chain committed.
+	// May be empty if no acknowledgement has been written yet.
+	Acknowledgement []byte `json:"acknowledgement,omitempty"`
+}
+
+type IBCNextSequenceReceiveQuery struct {
+	PortID    string `json:"port_id"`
+	ChannelID string `json:"channel_id"`
+}
+
+// IBCNextSequenceReceiveResponse is the expected response to IBCNextSequenceReceiveQuery
+type IBCNextSequenceReceiveResponse struct {
+	Sequence uint64 `json:"sequence"`
+}
+
+type IBCDenomTraceQuery struct {
+	// Hash is the ICS-20 denom trace hash, with or without the "ibc/" prefix.
+	Hash string `json:"hash"`
+}
+
+// IBCDenomTraceResponse is the expected response to IBCDenomTraceQuery
+type IBCDenomTraceResponse struct {
+	// Path is the chain of ICS-20 port/channel pairs the token traveled over, e.g.
+	// "transfer/channel-0".
+	Path string `json:"path"`
+	// BaseDenom is the denom of the token on its origin chain.
+	BaseDenom string `json:"base_denom"`
+}
+
 type StakingQuery struct {
 	AllValidators  *AllValidatorsQuery  `json:"all_validators,omitempty"`
 	Validator      *ValidatorQuery      `json:"validator,omitempty"`
@@ -271,6 +463,14 @@ type DistributionQuery struct {
 	DelegationTotalRewards *DelegationTotalRewardsQuery `json:"delegation_total_rewards,omitempty"`
 	// See <https://github.com/cosmos/cosmos-sdk/blob/b0acf60e6c39f7ab023841841fc0b751a12c13ff/proto/cosmos/distribution/v1beta1/query.proto#L202-L210>
 	DelegatorValidators *DelegatorValidatorsQuery `json:"delegator_validators,omitempty"`
+	// See <https://github.com/cosmos/cosmos-sdk/blob/c74e2887b0b73e81d48c2f33e6b1020090089ee0/proto/cosmos/distribution/v1beta1/query.proto#L94-L101>
+	CommunityPool *CommunityPoolQuery `json:"community_pool,omitempty"`
+	// See <https://github.com/cosmos/cosmos-sdk/blob/c74e2887b0b73e81d48c2f33e6b1020090089ee0/proto/cosmos/distribution/v1beta1/query.proto#L103-L112>
+	ValidatorCommission *ValidatorCommissionQuery `json:"validator_commission,omitempty"`
+	// See <https://github.com/cosmos/cosmos-sdk/blob/c74e2887b0b73e81d48c2f33e6b1020090089ee0/proto/cosmos/distribution/v1beta1/query.proto#L114-L123>
+	ValidatorOutstandingRewards *ValidatorOutstandingRewardsQuery `json:"validator_outstanding_rewards,omitempty"`
+	// See <https://github.com/cosmos/cosmos-sdk/blob/c74e2887b0b73e81d48c2f33e6b1020090089ee0/proto/cosmos/distribution/v1beta1/query.proto#L125-L140>
+	ValidatorSlashes *ValidatorSlashesQuery `json:"validator_slashes,omitempty"`
 }
 
 type DelegatorWithdrawAddressQuery struct {
@@ -332,6 +532,55 @@ type BondedDenomResponse struct {
 	Denom string `json:"denom"`
 }
 
+type CommunityPoolQuery struct{}
+
+// CommunityPoolResponse is the expected response to CommunityPoolQuery
+type CommunityPoolResponse struct {
+	Pool []DecCoin `json:"pool"`
+}
+
+type ValidatorCommissionQuery struct {
+	ValidatorAddress string `json:"validator_address"`
+}
+
+// ValidatorCommissionResponse is the expected response to ValidatorCommissionQuery
+type ValidatorCommissionResponse struct {
+	Commission []DecCoin `json:"commission"`
+}
+
+type ValidatorOutstandingRewardsQuery struct {
+	ValidatorAddress string `json:"validator_address"`
+}
+
+// ValidatorOutstandingRewardsResponse is the expected response to ValidatorOutstandingRewardsQuery
+type ValidatorOutstandingRewardsResponse struct {
+	Rewards []DecCoin `json:"rewards"`
+}
+
+type ValidatorSlashesQuery struct {
+	ValidatorAddress string `json:"validator_address"`
+	StartingHeight   uint64 `json:"starting_height"`
+	EndingHeight     uint64 `json:"ending_height"`
+	// Pagination is an optional argument.
+	// Default pagination will be used if this is omitted
+	Pagination *PageRequest `json:"pagination,omitempty"`
+}
+
+// ValidatorSlashesResponse is the expected response to ValidatorSlashesQuery
+type ValidatorSlashesResponse struct {
+	Slashes []ValidatorSlashEvent `json:"slashes"`
+	// NextKey is the key to be passed to PageRequest.key to
+	// query the next page most efficiently. It will be empty if
+	// there are no more results.
+	NextKey []byte `json:"next_key,omitempty"`
+}
+
+type ValidatorSlashEvent struct {
+	ValidationPeriod uint64 `json:"validation_period"`
+	// decimal string, eg "0.02"
+	Fraction string `json:"fraction"`
+}
+
 // StargateQuery is encoded the same way as abci_query, with path and protobuf encoded request data.
 // The format is defined in [ADR-21](https://github.com/cosmos/cosmos-sdk/blob/master/docs/architecture/adr-021-protobuf-query-encoding.md).
 // The response is supposed to always be protobuf encoded data, but is JSON encoded on some chains.
@@ -457,3 +706,272 @@ type CodeInfoResponse struct {
 	// Everything else is considered a bug.
 	Checksum Checksum `json:"checksum"`
 }
+
+// FeegrantQuery is the counterpart of the Cosmos SDK feegrant module's
+// `cosmos.feegrant.v1beta1.Query` gRPC service.
+type FeegrantQuery struct {
+	// See <https://github.com/cosmos/cosmos-sdk/blob/v0.46.0/proto/cosmos/feegrant/v1beta1/query.proto#L23-L31>
+	Allowance *AllowanceQuery `json:"allowance,omitempty"`
+	// See <https://github.com/cosmos/cosmos-sdk/blob/v0.46.0/proto/cosmos/feegrant/v1beta1/query.proto#L33-L44>
+	Allowances *AllowancesQuery `json:"allowances,omitempty"`
+	// See <https://github.com/cosmos/cosmos-sdk/blob/v0.50.0/proto/cosmos/feegrant/v1beta1/query.proto#L46-L57>
+	AllowancesByGranter *AllowancesByGranterQuery `json:"allowances_by_granter,omitempty"`
+}
+
+type AllowanceQuery struct {
+	Granter string `json:"granter"`
+	Grantee string `json:"grantee"`
+}
+
+// AllowanceResponse is the expected response to AllowanceQuery
+type AllowanceResponse struct {
+	Allowance Grant `json:"allowance"`
+}
+
+type AllowancesQuery struct {
+	Grantee string `json:"grantee"`
+	// Pagination is an optional argument.
+	// Default pagination will be used if this is omitted
+	Pagination *PageRequest `json:"pagination,omitempty"`
+}
+
+// AllowancesResponse is the expected response to AllowancesQuery
+type AllowancesResponse struct {
+	Allowances []Grant `json:"allowances"`
+	// NextKey is the key to be passed to PageRequest.key to
+	// query the next page most efficiently. It will be empty if
+	// there are no more results.
+	NextKey []byte `json:"next_key,omitempty"`
+}
+
+type AllowancesByGranterQuery struct {
+	Granter string `json:"granter"`
+	// Pagination is an optional argument.
+	// Default pagination will be used if this is omitted
+	Pagination *PageRequest `json:"pagination,omitempty"`
+}
+
+// AllowancesByGranterResponse is the expected response to AllowancesByGranterQuery
+type AllowancesByGranterResponse struct {
+	Allowances []Grant `json:"allowances"`
+	// NextKey is the key to be passed to PageRequest.key to
+	// query the next page most efficiently. It will be empty if
+	// there are no more results.
+	NextKey []byte `json:"next_key,omitempty"`
+}
+
+// Grant is stored in the KVStore to record a grant with full context
+type Grant struct {
+	Granter string `json:"granter"`
+	Grantee string `json:"grantee"`
+	// Allowance is a protobuf-encoded `Any` containing the fee allowance implementation
+	// (e.g. BasicAllowance, PeriodicAllowance, AllowedMsgAllowance).
+	Allowance []byte `json:"allowance"`
+}
+
+// AuthQuery is the counterpart of the Cosmos SDK auth module's
+// `cosmos.auth.v1beta1.Query` gRPC service.
+type AuthQuery struct {
+	// See <https://github.com/cosmos/cosmos-sdk/blob/v0.46.0/proto/cosmos/auth/v1beta1/query.proto#L33-L41>
+	Account *AccountQuery `json:"account,omitempty"`
+	// See <https://github.com/cosmos/cosmos-sdk/blob/v0.46.0/proto/cosmos/auth/v1beta1/query.proto#L43-L48>
+	Params *AuthParamsQuery `json:"params,omitempty"`
+}
+
+type AccountQuery struct {
+	Address string `json:"address"`
+}
+
+// AccountResponse is the expected response to AccountQuery
+type AccountResponse struct {
+	Address string `json:"address"`
+	// PubKey is the protobuf-encoded `Any` containing the account's public key.
+	// It is empty if the account has not signed a transaction yet.
+	PubKey        []byte `json:"pub_key,omitempty"`
+	AccountNumber uint64 `json:"account_number"`
+	Sequence      uint64 `json:"sequence"`
+}
+
+type AuthParamsQuery struct{}
+
+// AuthParamsResponse is the expected response to AuthParamsQuery
+type AuthParamsResponse struct {
+	MaxMemoCharacters      uint64 `json:"max_memo_characters"`
+	TxSigLimit             uint64 `json:"tx_sig_limit"`
+	TxSizeCostPerByte      uint64 `json:"tx_size_cost_per_byte"`
+	SigVerifyCostED25519   uint64 `json:"sig_verify_cost_ed25519"`
+	SigVerifyCostSecp256k1 uint64 `json:"sig_verify_cost_secp256k1"`
+}
+
+// BatchQuery lets a contract dispatch many QueryRequests in a single call into the host,
+// avoiding one FFI round-trip per sub-query.
+type BatchQuery struct {
+	// Requests is capped at maxBatchQueries entries; a longer batch is rejected
+	// outright rather than relying on gas exhaustion to bound its size, since both
+	// the in-memory BatchResponse and its JSON encoding would otherwise be unbounded.
+	// Nested batch queries (a sub-request with Batch set) are also rejected.
+	Requests []QueryRequest `json:"requests"`
+	// StopOnError controls how a failing sub-query is handled. If true, the first
+	// sub-query error aborts the whole batch. If false, the error is captured in the
+	// corresponding QueryResult and the remaining sub-queries still run.
+	StopOnError bool `json:"stop_on_error"`
+}
+
+// BatchResponse is the expected response to BatchQuery. Results are in the same
+// order as BatchQuery.Requests.
+type BatchResponse struct {
+	Results []QueryResult `json:"results"`
+}
+
+// QueryCacheHint opts a query into the CachingQuerier's block-scoped cache.
+// Key should uniquely identify the query within Scope from the contract's point of
+// view (e.g. a stable string derived from the query's semantic arguments); Scope
+// lets the host group entries so it can invalidate just one family of queries, e.g.
+// "bank" or a specific contract address, without flushing the whole cache.
+type QueryCacheHint struct {
+	Key   string `json:"key"`
+	Scope string `json:"scope"`
+}
+
+// queryCacheHitGas is the fixed gas cost charged for a CachingQuerier cache hit. It
+// approximates the cost of a map lookup rather than the cost of the underlying query,
+// which is the whole point of caching.
+const queryCacheHitGas uint64 = 100
+
+// CachingQuerier wraps a Querier with a block-scoped cache keyed by QueryRequest.CacheHint.
+// A single CachingQuerier is meant to be constructed once per block and reused across
+// every message/transaction executed within that block, so cache hits carry over between
+// executions; each execution typically gets its own Querier bound to its own gas meter
+// and state branch, so the host must call SetQuerier to rebind the wrapped Querier before
+// each execution rather than constructing a new CachingQuerier (which would reset the
+// cache and lose the "per-block" benefit). The resulting CachingQuerier is then passed
+// to RustQuery in place of the underlying Querier.
+//
+// Only the read-only query variants listed in isCacheableQuery are ever served from cache;
+// everything else always goes to the wrapped Querier.
+type CachingQuerier struct {
+	querier Querier
+
+	mu       sync.Mutex
+	cache    map[string]map[string][]byte // scope -> (key + canonical request) -> response bytes
+	cacheGas uint64
+}
+
+// NewCachingQuerier wraps querier with an empty cache.
+func NewCachingQuerier(querier Querier) *CachingQuerier {
+	return &CachingQuerier{
+		querier: querier,
+		cache:   make(map[string]map[string][]byte),
+	}
+}
+
+// SetQuerier rebinds the wrapped Querier, keeping the existing cache intact. The host
+// calls this between executions within the same block (each execution has its own
+// Querier bound to its own gas meter and state branch) instead of constructing a new
+// CachingQuerier, which would otherwise discard the cache built up so far this block.
+func (q *CachingQuerier) SetQuerier(querier Querier) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.querier = querier
+}
+
+// querierRef returns the currently wrapped Querier, guarding against a concurrent
+// SetQuerier call.
+func (q *CachingQuerier) querierRef() Querier {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.querier
+}
+
+func (q *CachingQuerier) Query(request QueryRequest, gasLimit uint64) ([]byte, error) {
+	hint := request.CacheHint
+	if hint == nil || !isCacheableQuery(request) {
+		return q.querierRef().Query(request, gasLimit)
+	}
+
+	key, err := cacheEntryKey(hint, request)
+	if err != nil {
+		return q.querierRef().Query(request, gasLimit)
+	}
+
+	q.mu.Lock()
+	if scoped, ok := q.cache[hint.Scope]; ok {
+		if cached, ok := scoped[key]; ok {
+			q.mu.Unlock()
+			// A cache hit still has to respect the caller's gas limit: if the
+			// contract doesn't have queryCacheHitGas left, fall through to the
+			// real querier so it gets the same out-of-gas treatment a cache
+			// miss would, instead of a free successful result.
+			if gasLimit < queryCacheHitGas {
+				return q.querierRef().Query(request, gasLimit)
+			}
+			q.mu.Lock()
+			q.cacheGas += queryCacheHitGas
+			q.mu.Unlock()
+			return cached, nil
+		}
+	}
+	q.mu.Unlock()
+
+	bz, err := q.querierRef().Query(request, gasLimit)
+	if err != nil {
+		return bz, err
+	}
+
+	q.mu.Lock()
+	if q.cache[hint.Scope] == nil {
+		q.cache[hint.Scope] = make(map[string][]byte)
+	}
+	q.cache[hint.Scope][key] = bz
+	q.mu.Unlock()
+	return bz, nil
+}
+
+// GasConsumed reports the wrapped querier's gas plus the fixed cost of every cache hit
+// served so far, so RustQuery's before/after gas accounting still charges cache hits.
+func (q *CachingQuerier) GasConsumed() uint64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.querier.GasConsumed() + q.cacheGas
+}
+
+// InvalidateScope flushes every cached entry under scope. Hosts call this when a
+// message mutates state that scope's cached queries depend on.
+func (q *CachingQuerier) InvalidateScope(scope string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.cache, scope)
+}
+
+// InvalidateAll flushes the entire cache. Hosts call this on commit, when starting
+// a new block.
+func (q *CachingQuerier) InvalidateAll() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.cache = make(map[string]map[string][]byte)
+}
+
+// isCacheableQuery reports whether request is one of the read-only variants eligible
+// for CachingQuerier caching: Bank, Staking, Distribution, Auth, and the read-only
+// Wasm.Smart/Wasm.Raw variants.
+func isCacheableQuery(request QueryRequest) bool {
+	switch {
+	case request.Bank != nil, request.Staking != nil, request.Distribution != nil, request.Auth != nil:
+		return true
+	case request.Wasm != nil && (request.Wasm.Smart != nil || request.Wasm.Raw != nil):
+		return true
+	default:
+		return false
+	}
+}
+
+// cacheEntryKey derives the cache map key for request under hint: hint.Key combined
+// with the canonical JSON encoding of the full request, so two requests with the same
+// hint.Key but different arguments never collide.
+func cacheEntryKey(hint *QueryCacheHint, request QueryRequest) (string, error) {
+	canonical, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	return hint.Key + "\x00" + string(canonical), nil
+}
@@ -0,0 +1,202 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// stubQuerier is a minimal Querier used to drive runBatchQuery and CachingQuerier
+// without depending on any real host implementation.
+type stubQuerier struct {
+	gas     uint64
+	calls   []QueryRequest
+	handler func(request QueryRequest) (bz []byte, gasUsed uint64, err error)
+}
+
+func (s *stubQuerier) Query(request QueryRequest, gasLimit uint64) ([]byte, error) {
+	s.calls = append(s.calls, request)
+	bz, used, err := s.handler(request)
+	s.gas += used
+	return bz, err
+}
+
+func (s *stubQuerier) GasConsumed() uint64 {
+	return s.gas
+}
+
+func denomQuery(denom string) QueryRequest {
+	return QueryRequest{Bank: &BankQuery{Supply: &SupplyQuery{Denom: denom}}}
+}
+
+func unmarshalBatchResponse(t *testing.T, result QuerierResult) BatchResponse {
+	t.Helper()
+	if result.Ok == nil {
+		t.Fatalf("expected an Ok QuerierResult, got %+v", result)
+	}
+	if result.Ok.Err != "" {
+		t.Fatalf("expected no top-level query error, got %q", result.Ok.Err)
+	}
+	var resp BatchResponse
+	if err := json.Unmarshal(result.Ok.Ok, &resp); err != nil {
+		t.Fatalf("failed to unmarshal BatchResponse: %v", err)
+	}
+	return resp
+}
+
+func TestRunBatchQueryHappyPathPreservesOrder(t *testing.T) {
+	querier := &stubQuerier{
+		handler: func(request QueryRequest) ([]byte, uint64, error) {
+			return []byte(request.Bank.Supply.Denom), 10, nil
+		},
+	}
+	batch := BatchQuery{Requests: []QueryRequest{denomQuery("atom"), denomQuery("osmo"), denomQuery("juno")}}
+
+	resp := unmarshalBatchResponse(t, runBatchQuery(querier, batch, 1000))
+
+	if len(resp.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(resp.Results))
+	}
+	want := []string{"atom", "osmo", "juno"}
+	for i, w := range want {
+		if got := string(resp.Results[i].Ok); got != w {
+			t.Errorf("result %d: got %q, want %q", i, got, w)
+		}
+	}
+}
+
+func TestRunBatchQueryStopOnErrorFalseCollectsPerEntryErrors(t *testing.T) {
+	querier := &stubQuerier{
+		handler: func(request QueryRequest) ([]byte, uint64, error) {
+			denom := request.Bank.Supply.Denom
+			if denom == "bad" {
+				return nil, 5, errors.New("denom not found")
+			}
+			return []byte(denom), 5, nil
+		},
+	}
+	batch := BatchQuery{
+		Requests:    []QueryRequest{denomQuery("atom"), denomQuery("bad"), denomQuery("osmo")},
+		StopOnError: false,
+	}
+
+	resp := unmarshalBatchResponse(t, runBatchQuery(querier, batch, 1000))
+
+	if len(resp.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(resp.Results))
+	}
+	if resp.Results[1].Err != "denom not found" {
+		t.Errorf("expected middle entry to carry the error, got %+v", resp.Results[1])
+	}
+	if string(resp.Results[2].Ok) != "osmo" {
+		t.Errorf("expected batch to continue after the error, got %+v", resp.Results[2])
+	}
+}
+
+func TestRunBatchQueryStopOnErrorTrueAbortsBatch(t *testing.T) {
+	querier := &stubQuerier{
+		handler: func(request QueryRequest) ([]byte, uint64, error) {
+			denom := request.Bank.Supply.Denom
+			if denom == "bad" {
+				return nil, 5, errors.New("denom not found")
+			}
+			return []byte(denom), 5, nil
+		},
+	}
+	batch := BatchQuery{
+		Requests:    []QueryRequest{denomQuery("atom"), denomQuery("bad"), denomQuery("osmo")},
+		StopOnError: true,
+	}
+
+	result := runBatchQuery(querier, batch, 1000)
+
+	if result.Ok == nil || result.Ok.Err != "denom not found" {
+		t.Fatalf("expected the batch to surface the first error, got %+v", result)
+	}
+	if len(querier.calls) != 2 {
+		t.Fatalf("expected the batch to stop after the failing sub-query, got %d calls", len(querier.calls))
+	}
+}
+
+func TestRunBatchQueryRejectsNestedBatch(t *testing.T) {
+	querier := &stubQuerier{
+		handler: func(request QueryRequest) ([]byte, uint64, error) {
+			return []byte("ok"), 1, nil
+		},
+	}
+	nested := BatchQuery{Requests: []QueryRequest{denomQuery("atom")}}
+	batch := BatchQuery{Requests: []QueryRequest{denomQuery("atom"), {Batch: &nested}}}
+
+	resp := unmarshalBatchResponse(t, runBatchQuery(querier, batch, 1000))
+
+	if len(resp.Results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(resp.Results))
+	}
+	if resp.Results[1].Err == "" {
+		t.Errorf("expected the nested batch entry to carry an error, got %+v", resp.Results[1])
+	}
+	if len(querier.calls) != 1 {
+		t.Errorf("expected the nested batch to never reach the querier, got %d calls", len(querier.calls))
+	}
+}
+
+func TestRunBatchQueryRejectsOverMaxBatchSize(t *testing.T) {
+	querier := &stubQuerier{
+		handler: func(request QueryRequest) ([]byte, uint64, error) {
+			return []byte("ok"), 1, nil
+		},
+	}
+	requests := make([]QueryRequest, maxBatchQueries+1)
+	for i := range requests {
+		requests[i] = denomQuery(fmt.Sprintf("denom-%d", i))
+	}
+	batch := BatchQuery{Requests: requests}
+
+	result := runBatchQuery(querier, batch, 1_000_000)
+
+	if result.Ok == nil || result.Ok.Err == "" {
+		t.Fatalf("expected an error result for an oversized batch, got %+v", result)
+	}
+	if len(querier.calls) != 0 {
+		t.Errorf("expected an oversized batch to be rejected before querying, got %d calls", len(querier.calls))
+	}
+}
+
+func TestRunBatchQueryGasAccountingClampsAtZero(t *testing.T) {
+	// Track the gasLimit passed into each sub-query via a wrapping stub.
+	var gotLimits []uint64
+	tracking := &trackingQuerier{
+		stubQuerier: stubQuerier{handler: func(request QueryRequest) ([]byte, uint64, error) {
+			return []byte("ok"), 80, nil
+		}},
+		onQuery: func(gasLimit uint64) { gotLimits = append(gotLimits, gasLimit) },
+	}
+
+	batch := BatchQuery{Requests: []QueryRequest{denomQuery("a"), denomQuery("b"), denomQuery("c")}}
+	runBatchQuery(tracking, batch, 100)
+
+	if len(gotLimits) != 3 {
+		t.Fatalf("expected 3 sub-queries, got %d", len(gotLimits))
+	}
+	if gotLimits[0] != 100 {
+		t.Errorf("first sub-query should see the full gas limit, got %d", gotLimits[0])
+	}
+	if gotLimits[1] != 20 {
+		t.Errorf("second sub-query should see the remaining gas (100-80), got %d", gotLimits[1])
+	}
+	if gotLimits[2] != 0 {
+		t.Errorf("third sub-query should see a clamped-to-zero gas limit, got %d", gotLimits[2])
+	}
+}
+
+// trackingQuerier wraps stubQuerier to additionally record the gasLimit passed to Query.
+type trackingQuerier struct {
+	stubQuerier
+	onQuery func(gasLimit uint64)
+}
+
+func (t *trackingQuerier) Query(request QueryRequest, gasLimit uint64) ([]byte, error) {
+	t.onQuery(gasLimit)
+	return t.stubQuerier.Query(request, gasLimit)
+}
@@ -0,0 +1,136 @@
+package types
+
+import "testing"
+
+func TestCachingQuerierMissThenHit(t *testing.T) {
+	querier := &stubQuerier{
+		handler: func(request QueryRequest) ([]byte, uint64, error) {
+			return []byte("balance:" + request.Bank.Supply.Denom), 50, nil
+		},
+	}
+	cq := NewCachingQuerier(querier)
+	request := denomQuery("atom")
+	request.CacheHint = &QueryCacheHint{Key: "atom-supply", Scope: "bank"}
+
+	bz1, err := cq.Query(request, 1000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bz2, err := cq.Query(request, 1000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(bz1) != string(bz2) {
+		t.Errorf("expected cached response to match original, got %q vs %q", bz1, bz2)
+	}
+	if len(querier.calls) != 1 {
+		t.Errorf("expected the underlying querier to be hit exactly once, got %d calls", len(querier.calls))
+	}
+	if cq.GasConsumed() != 50+queryCacheHitGas {
+		t.Errorf("expected gas to include the miss plus the cache-hit charge, got %d", cq.GasConsumed())
+	}
+}
+
+func TestCachingQuerierBypassesNonCacheableVariants(t *testing.T) {
+	querier := &stubQuerier{
+		handler: func(request QueryRequest) ([]byte, uint64, error) {
+			return []byte("stargate-response"), 1, nil
+		},
+	}
+	cq := NewCachingQuerier(querier)
+	request := QueryRequest{
+		Stargate:  &StargateQuery{Path: "/some.Query/Method"},
+		CacheHint: &QueryCacheHint{Key: "k", Scope: "s"},
+	}
+
+	if _, err := cq.Query(request, 1000); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := cq.Query(request, 1000); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(querier.calls) != 2 {
+		t.Errorf("expected every call to reach the querier for a non-cacheable variant, got %d calls", len(querier.calls))
+	}
+}
+
+func TestCachingQuerierInvalidateScope(t *testing.T) {
+	querier := &stubQuerier{
+		handler: func(request QueryRequest) ([]byte, uint64, error) {
+			return []byte("balance"), 10, nil
+		},
+	}
+	cq := NewCachingQuerier(querier)
+	request := denomQuery("atom")
+	request.CacheHint = &QueryCacheHint{Key: "atom-supply", Scope: "bank"}
+
+	if _, err := cq.Query(request, 1000); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cq.InvalidateScope("bank")
+	if _, err := cq.Query(request, 1000); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(querier.calls) != 2 {
+		t.Errorf("expected invalidation to force a fresh query, got %d calls", len(querier.calls))
+	}
+}
+
+func TestCachingQuerierHitRespectsGasLimit(t *testing.T) {
+	querier := &stubQuerier{
+		handler: func(request QueryRequest) ([]byte, uint64, error) {
+			return []byte("balance"), 10, nil
+		},
+	}
+	cq := NewCachingQuerier(querier)
+	request := denomQuery("atom")
+	request.CacheHint = &QueryCacheHint{Key: "atom-supply", Scope: "bank"}
+
+	if _, err := cq.Query(request, 1000); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A second call with less gas than queryCacheHitGas must not be served from
+	// cache for free; it should fall through to the real querier again.
+	if _, err := cq.Query(request, queryCacheHitGas-1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(querier.calls) != 2 {
+		t.Errorf("expected the low-gas call to bypass the cache, got %d calls", len(querier.calls))
+	}
+}
+
+func TestCachingQuerierSetQuerierPreservesCache(t *testing.T) {
+	first := &stubQuerier{
+		handler: func(request QueryRequest) ([]byte, uint64, error) {
+			return []byte("first-exec"), 10, nil
+		},
+	}
+	cq := NewCachingQuerier(first)
+	request := denomQuery("atom")
+	request.CacheHint = &QueryCacheHint{Key: "atom-supply", Scope: "bank"}
+
+	if _, err := cq.Query(request, 1000); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	second := &stubQuerier{
+		handler: func(request QueryRequest) ([]byte, uint64, error) {
+			t.Fatal("second querier should never be hit for a cached entry")
+			return nil, 0, nil
+		},
+	}
+	cq.SetQuerier(second)
+
+	bz, err := cq.Query(request, 1000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(bz) != "first-exec" {
+		t.Errorf("expected the cached entry from before SetQuerier, got %q", bz)
+	}
+}